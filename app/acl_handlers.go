@@ -0,0 +1,82 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/hlog"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+)
+
+// handleACLFindAll handles GET requests for the /api/v1/acl endpoint
+// and returns every policy rule currently enforced
+func (s *Server) handleACLFindAll(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	response, err := s.ACLService.FindAll(r.Context())
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	respondJSON(w, logger, http.StatusOK, response)
+}
+
+// handleACLCreate handles POST requests for the /api/v1/acl endpoint
+// and adds a new policy rule
+func (s *Server) handleACLCreate(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	// Declare rb as an instance of auth.AccessControlList
+	rb := new(auth.AccessControlList)
+
+	// Decode JSON HTTP request body into a json.Decoder type
+	// and unmarshal that into rb
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	defer r.Body.Close()
+	// Call decoderErr to determine if body is nil, json is malformed
+	// or any other error
+	err = decoderErr(err)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	response, err := s.ACLService.Add(r.Context(), *rb)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	respondJSON(w, logger, http.StatusOK, response)
+}
+
+// handleACLDelete handles DELETE requests for the /api/v1/acl endpoint
+// and removes a policy rule
+func (s *Server) handleACLDelete(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	// Declare rb as an instance of auth.AccessControlList
+	rb := new(auth.AccessControlList)
+
+	// Decode JSON HTTP request body into a json.Decoder type
+	// and unmarshal that into rb
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	defer r.Body.Close()
+	// Call decoderErr to determine if body is nil, json is malformed
+	// or any other error
+	err = decoderErr(err)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	err = s.ACLService.Delete(r.Context(), *rb)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -10,17 +11,20 @@ import (
 
 	"github.com/rs/zerolog/hlog"
 
-	"github.com/gilcrest/go-api-basic/domain/errs"
 	"github.com/gilcrest/go-api-basic/service"
 )
 
+// jwtTokenTTL is the lifetime given to self-issued access tokens
+// minted by handleTokenCreate
+const jwtTokenTTL = 15 * time.Minute
+
 // CreateMovie is a HandlerFunc used to create a Movie
 func (s *Server) handleMovieCreate(w http.ResponseWriter, r *http.Request) {
 	logger := *hlog.FromRequest(r)
 
 	u, err := user.FromRequest(r)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
@@ -36,22 +40,19 @@ func (s *Server) handleMovieCreate(w http.ResponseWriter, r *http.Request) {
 	// or any other error
 	err = decoderErr(err)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
 	response, err := s.CreateMovieService.Create(r.Context(), rb, u)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	s.publishMovieEvent(r.Context(), logger, service.MovieEventCreated, response.ExternalID, response)
+
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleMovieUpdate handles PUT requests for the /movies/{id} endpoint
@@ -62,7 +63,7 @@ func (s *Server) handleMovieUpdate(w http.ResponseWriter, r *http.Request) {
 
 	u, err := user.FromRequest(r)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
@@ -83,7 +84,7 @@ func (s *Server) handleMovieUpdate(w http.ResponseWriter, r *http.Request) {
 	// or any other error
 	err = decoderErr(err)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
@@ -93,16 +94,13 @@ func (s *Server) handleMovieUpdate(w http.ResponseWriter, r *http.Request) {
 
 	response, err := s.UpdateMovieService.Update(r.Context(), rb, u)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	s.publishMovieEvent(r.Context(), logger, service.MovieEventUpdated, extlid, response)
+
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleMovieDelete handles DELETE requests for the /movies/{id} endpoint
@@ -119,16 +117,13 @@ func (s *Server) handleMovieDelete(w http.ResponseWriter, r *http.Request) {
 
 	response, err := s.DeleteMovieService.Delete(r.Context(), extlID)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	s.publishMovieEvent(r.Context(), logger, service.MovieEventDeleted, extlID, response)
+
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleFindMovieByID handles GET requests for the /movies/{id} endpoint
@@ -145,16 +140,11 @@ func (s *Server) handleFindMovieByID(w http.ResponseWriter, r *http.Request) {
 
 	response, err := s.FindMovieService.FindMovieByID(r.Context(), extlID)
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleFindAllMovies handles GET requests for the /movies endpoint and finds
@@ -165,36 +155,26 @@ func (s *Server) handleFindAllMovies(w http.ResponseWriter, r *http.Request) {
 
 	response, err := s.FindMovieService.FindAllMovies(r.Context())
 	if err != nil {
-		errs.HTTPErrorResponse(w, logger, err)
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleLoggerRead handles GET requests for the /logger endpoint
 func (s *Server) handleLoggerRead(w http.ResponseWriter, r *http.Request) {
-	lgr := *hlog.FromRequest(r)
+	logger := *hlog.FromRequest(r)
 
 	response := s.LoggerService.Read()
 
-	// Encode response struct to JSON for the response body
-	err := json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, lgr, errs.E(errs.Internal, err))
-		return
-	}
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // handleLoggerUpdate handles PUT requests for the /logger endpoint
 // and updates the logger globals
 func (s *Server) handleLoggerUpdate(w http.ResponseWriter, r *http.Request) {
-	lgr := *hlog.FromRequest(r)
+	logger := *hlog.FromRequest(r)
 
 	// Declare rb as an instance of service.LoggerRequest
 	rb := new(service.LoggerRequest)
@@ -207,22 +187,39 @@ func (s *Server) handleLoggerUpdate(w http.ResponseWriter, r *http.Request) {
 	// or any other error
 	err = decoderErr(err)
 	if err != nil {
-		errs.HTTPErrorResponse(w, lgr, err)
+		respondError(w, logger, err)
 		return
 	}
 
 	response, err := s.LoggerService.Update(rb)
 	if err != nil {
-		errs.HTTPErrorResponse(w, lgr, err)
+		respondError(w, logger, err)
+		return
+	}
+
+	respondJSON(w, logger, http.StatusOK, response)
+}
+
+// handleTokenCreate handles POST requests for the /api/v1/token
+// endpoint and mints a self-issued JWT access token for the
+// authenticated user, letting the client avoid a Google Oauth2
+// round-trip on subsequent requests
+func (s *Server) handleTokenCreate(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	u, err := user.FromRequest(r)
+	if err != nil {
+		respondError(w, logger, err)
 		return
 	}
 
-	// Encode response struct to JSON for the response body
-	err = json.NewEncoder(w).Encode(response)
+	response, err := s.JWTIssuerService.CreateToken(r.Context(), u.Email, jwtTokenTTL)
 	if err != nil {
-		errs.HTTPErrorResponse(w, lgr, errs.E(errs.Internal, err))
+		respondError(w, logger, err)
 		return
 	}
+
+	respondJSON(w, logger, http.StatusOK, response)
 }
 
 // Ping handles GET requests for the /ping endpoint
@@ -235,10 +232,5 @@ func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
 
 	response := s.PingService.Ping(ctx, logger)
 
-	// Encode response struct to JSON for the response body
-	err := json.NewEncoder(w).Encode(response)
-	if err != nil {
-		errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
-		return
-	}
+	respondJSON(w, logger, http.StatusOK, response)
 }
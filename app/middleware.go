@@ -0,0 +1,142 @@
+package app
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// errRateLimited is returned when a caller has exceeded its rate
+// limit
+var errRateLimited = errors.New("rate limit exceeded")
+
+// errUnauthenticated is returned when a request cannot be
+// authenticated by the server's configured auth.Authenticator
+var errUnauthenticated = errors.New("request could not be authenticated")
+
+// Authenticate is middleware that authenticates the Bearer token on
+// the incoming request using whichever auth.Authenticator the server
+// was wired with, then sets the resulting user.User on the request
+// context for downstream handlers. Operators can swap identity
+// providers (Google, OIDC, Keystone, self-issued JWT, ...) by changing
+// which Authenticator is injected, with no change to this middleware.
+func (s *Server) Authenticate(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := *hlog.FromRequest(r)
+
+		at, ok := auth.AccessTokenFromRequest(r)
+		if !ok {
+			s.challengeUnauthenticated(w, logger, r)
+			return
+		}
+
+		u, scopes, err := s.Authenticator.Authenticate(r.Context(), at)
+		if err != nil {
+			s.challengeUnauthenticated(w, logger, r)
+			return
+		}
+
+		ctx := user.CtxWithUser(r.Context(), u)
+		if len(scopes) > 0 {
+			ctx = auth.CtxWithAccessToken(ctx, auth.NewScopedAccessToken(at.Token, at.TokenType, scopes))
+		}
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// challengeUnauthenticated writes a 401 response whose WWW-Authenticate
+// header reflects the active Authenticator's scheme and realm, so
+// clients know which credentials to present.
+func (s *Server) challengeUnauthenticated(w http.ResponseWriter, logger zerolog.Logger, r *http.Request) {
+	realm, ok := auth.RealmFromRequest(r)
+	if !ok {
+		realm = auth.DefaultRealm
+	}
+
+	w.Header().Set("WWW-Authenticate", auth.Challenge(s.Authenticator, realm))
+	respondError(w, logger, errs.NewUnauthorizedError(errUnauthenticated))
+}
+
+// RateLimit returns middleware that enforces a rate limit via
+// s.RateLimiter, keyed on the authenticated user's email, falling back
+// to the caller's remote IP for unauthenticated routes like /ping. It
+// must run after Authenticate so user.FromRequest is populated.
+//
+// s.RateLimiter is read inside the returned handler, not when RateLimit
+// is called, so routes() can wire this middleware before the Server's
+// fields are populated; NewServer registers routes before callers set
+// RateLimiter, and reading it eagerly would bake in a nil Limiter.
+func (s *Server) RateLimit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := *hlog.FromRequest(r)
+
+		allowed, limit, remaining, reset, err := s.RateLimiter.Allow(r.Context(), rateLimitKey(r))
+		if err != nil {
+			respondError(w, logger, errs.E(errs.Internal, err))
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(reset).Seconds())))
+			respondError(w, logger, errs.E(errs.TooManyRequests, errRateLimited))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Authorize is middleware that requires the authenticated user (set by
+// Authenticate) to be permitted, via s.Authorizer, to perform the
+// request's method against r.URL.Path. Passing the actual request path
+// as the Casbin object (rather than a route's collapsed base path) is
+// what lets policy rules like "/api/v1/movies/*" - matched via the
+// model's keyMatch2 matcher - decide which paths a resource covers.
+// Authorize must run after Authenticate so user.FromRequest is
+// populated.
+func (s *Server) Authorize(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := *hlog.FromRequest(r)
+
+		u, err := user.FromRequest(r)
+		if err != nil {
+			respondError(w, logger, err)
+			return
+		}
+
+		if err := s.Authorizer.Authorize(r.Context(), logger, u, r.URL.Path, r.Method); err != nil {
+			respondError(w, logger, err)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitKey returns the authenticated user's email if the request
+// carries one, falling back to the caller's remote IP
+func rateLimitKey(r *http.Request) string {
+	if u, err := user.FromRequest(r); err == nil {
+		return u.Email
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+// publishMovieEvent publishes a movie change event to s.MovieEventBus.
+// A publish failure is logged rather than returned, since the
+// mutation it describes has already succeeded and should still be
+// reported to the caller.
+func (s *Server) publishMovieEvent(ctx context.Context, logger zerolog.Logger, eventType service.MovieEventType, externalID string, movie interface{}) {
+	event := service.MovieEvent{
+		Type:       eventType,
+		ExternalID: externalID,
+		Movie:      movie,
+		OccurredAt: time.Now(),
+	}
+
+	if err := s.MovieEventBus.Publish(ctx, event); err != nil {
+		logger.Error().Err(err).Str("external_id", externalID).Msg("failed to publish movie event")
+	}
+}
+
+// movieEventsUpgrader upgrades /api/v1/movies/events connections that
+// request it to a WebSocket; callers that don't fall back to
+// Server-Sent Events in handleMovieEvents.
+var movieEventsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// handleMovieEvents handles GET requests for the
+// /api/v1/movies/events endpoint, streaming create/update/delete
+// events as they are published to s.MovieEventBus. It upgrades to a
+// WebSocket when requested, falling back to Server-Sent Events
+// otherwise. routes() registers it behind Authorize, which checks read
+// permission on the request's own path (/api/v1/movies/events), so the
+// authenticated user in its subscription context is always one with
+// read permission on that path.
+func (s *Server) handleMovieEvents(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	events, unsubscribe, err := s.MovieEventBus.Subscribe(r.Context())
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+	defer unsubscribe()
+
+	if websocket.IsWebSocketUpgrade(r) {
+		s.streamMovieEventsWebSocket(w, r, events)
+		return
+	}
+
+	s.streamMovieEventsSSE(w, r, events)
+}
+
+// streamMovieEventsWebSocket upgrades the connection and writes each
+// event as a JSON text frame until the client disconnects or the
+// request context is done
+func (s *Server) streamMovieEventsWebSocket(w http.ResponseWriter, r *http.Request, events <-chan service.MovieEvent) {
+	logger := *hlog.FromRequest(r)
+
+	conn, err := movieEventsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		respondError(w, logger, errs.E(errs.Internal, err))
+		return
+	}
+	defer conn.Close()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamMovieEventsSSE writes each event as a Server-Sent Events
+// `data:` frame until the client disconnects or the request context
+// is done
+func (s *Server) streamMovieEventsSSE(w http.ResponseWriter, r *http.Request, events <-chan service.MovieEvent) {
+	logger := *hlog.FromRequest(r)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, logger, errs.E(errs.Internal, "streaming unsupported by response writer"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+
+			if _, err := w.Write([]byte("data: " + string(payload) + "\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
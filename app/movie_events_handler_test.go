@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+func TestServer_streamMovieEventsSSE(t *testing.T) {
+	s := &Server{}
+
+	events := make(chan service.MovieEvent, 1)
+	events <- service.MovieEvent{Type: service.MovieEventCreated, ExternalID: "abc123"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lgr := zerolog.Nop()
+	ctx = lgr.WithContext(ctx)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/movies/events", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		s.streamMovieEventsSSE(w, r, events)
+		close(done)
+	}()
+
+	// Give the handler a moment to write the first event, then cancel
+	// the request context so the stream stops.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("streamMovieEventsSSE did not return after context cancellation")
+	}
+
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %s, want text/event-stream", got)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"external_id":"abc123"`) {
+		t.Errorf("body = %q, want it to contain the published event", body)
+	}
+}
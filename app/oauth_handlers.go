@@ -0,0 +1,144 @@
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/hlog"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+// oauthTokenResponse is the RFC 6749 access token response body
+// returned from handleOAuthToken
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+}
+
+// handleOAuthAuthorize handles GET requests for the /oauth/authorize
+// endpoint. The caller must already be authenticated (Google, OIDC,
+// ...); on success the user is redirected to the client's
+// redirect_uri with a short-lived authorization code.
+func (s *Server) handleOAuthAuthorize(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	u, err := user.FromRequest(r)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	q := r.URL.Query()
+	clientID := q.Get("client_id")
+	redirectURI := q.Get("redirect_uri")
+	scopes := strings.Fields(q.Get("scope"))
+
+	code, err := s.OAuthService.Authorize(r.Context(), clientID, redirectURI, u.Email, scopes)
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	redirectTo, err := url.Parse(redirectURI)
+	if err != nil {
+		respondError(w, logger, errs.E(errs.Internal, err))
+		return
+	}
+	rq := redirectTo.Query()
+	rq.Set("code", code.Code)
+	if state := q.Get("state"); state != "" {
+		rq.Set("state", state)
+	}
+	redirectTo.RawQuery = rq.Encode()
+
+	http.Redirect(w, r, redirectTo.String(), http.StatusFound)
+}
+
+// handleOAuthToken handles POST requests for the /oauth/token
+// endpoint and exchanges an authorization code or refresh token for
+// an access token, per RFC 6749.
+func (s *Server) handleOAuthToken(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	if err := r.ParseForm(); err != nil {
+		respondError(w, logger, errs.E(errs.Validation, err))
+		return
+	}
+
+	clientID := r.Form.Get("client_id")
+	clientSecret := r.Form.Get("client_secret")
+
+	var (
+		t   service.OAuthToken
+		err error
+	)
+	switch r.Form.Get("grant_type") {
+	case "authorization_code":
+		t, err = s.OAuthService.Token(r.Context(), clientID, clientSecret, r.Form.Get("code"))
+	case "refresh_token":
+		t, err = s.OAuthService.Refresh(r.Context(), clientID, clientSecret, r.Form.Get("refresh_token"))
+	default:
+		err = errs.E(errs.Validation, "unsupported grant_type")
+	}
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	response := oauthTokenResponse{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    auth.BearerTokenType,
+		Scope:        strings.Join(t.Scopes, " "),
+	}
+
+	respondJSON(w, logger, http.StatusOK, response)
+}
+
+// handleOAuthRevoke handles POST requests for the /oauth/revoke
+// endpoint, per RFC 7009. The caller must authenticate as the client
+// that owns token via client_id/client_secret, per RFC 7009 §2.1.
+func (s *Server) handleOAuthRevoke(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	if err := r.ParseForm(); err != nil {
+		respondError(w, logger, errs.E(errs.Validation, err))
+		return
+	}
+
+	err := s.OAuthService.Revoke(r.Context(), r.Form.Get("client_id"), r.Form.Get("client_secret"), r.Form.Get("token"))
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleOAuthIntrospect handles POST requests for the
+// /oauth/introspect endpoint, per RFC 7662. The caller must
+// authenticate as a registered client via client_id/client_secret,
+// per RFC 7662 §2.1.
+func (s *Server) handleOAuthIntrospect(w http.ResponseWriter, r *http.Request) {
+	logger := *hlog.FromRequest(r)
+
+	if err := r.ParseForm(); err != nil {
+		respondError(w, logger, errs.E(errs.Validation, err))
+		return
+	}
+
+	response, err := s.OAuthService.Introspect(r.Context(), r.Form.Get("client_id"), r.Form.Get("client_secret"), r.Form.Get("token"))
+	if err != nil {
+		respondError(w, logger, err)
+		return
+	}
+
+	respondJSON(w, logger, http.StatusOK, response)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+// errorEnvelope is the "error" object in the uniform response
+// envelope returned by every handler on failure
+type errorEnvelope struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// responseEnvelope is the uniform JSON shape returned by every
+// handler: exactly one of Data or Error is populated.
+type responseEnvelope struct {
+	Data  interface{}    `json:"data,omitempty"`
+	Error *errorEnvelope `json:"error,omitempty"`
+}
+
+// respondJSON writes data to w as a successful envelope with the
+// given HTTP status code
+func respondJSON(w http.ResponseWriter, lgr zerolog.Logger, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(responseEnvelope{Data: data}); err != nil {
+		lgr.Error().Err(err).Msg("failed to encode response body")
+	}
+}
+
+// respondError logs err's code/message/detail as structured fields,
+// then writes it to w as the uniform error envelope, using the HTTP
+// status that corresponds to err's errs.Kind
+func respondError(w http.ResponseWriter, lgr zerolog.Logger, err error) {
+	code, status, detail := errorEnvelopeFields(err)
+	message := err.Error()
+
+	lgr.Error().
+		Str("code", code).
+		Str("message", message).
+		Str("detail", detail).
+		Msg("handler error")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	env := responseEnvelope{Error: &errorEnvelope{Code: code, Message: message, Detail: detail}}
+	if err := json.NewEncoder(w).Encode(env); err != nil {
+		lgr.Error().Err(err).Msg("failed to encode error response body")
+	}
+}
+
+// errorEnvelopeFields maps err's errs.Kind onto a stable,
+// machine-readable error code, the HTTP status that code corresponds
+// to, and any nested detail message.
+func errorEnvelopeFields(err error) (code string, status int, detail string) {
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		return "internal", http.StatusInternalServerError, ""
+	}
+
+	if e.Err != nil {
+		detail = e.Err.Error()
+	}
+
+	switch e.Kind {
+	case errs.Unauthenticated:
+		return "unauthorized", http.StatusUnauthorized, detail
+	case errs.NotExist:
+		return "not_found", http.StatusNotFound, detail
+	case errs.Validation:
+		return "validation_failed", http.StatusBadRequest, detail
+	case errs.TooManyRequests:
+		return "too_many_requests", http.StatusTooManyRequests, detail
+	default:
+		return "internal", http.StatusInternalServerError, detail
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+func TestRespondJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	lgr := zerolog.Nop()
+
+	respondJSON(w, lgr, 200, map[string]string{"hello": "world"})
+
+	if w.Code != 200 {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %s, want application/json", got)
+	}
+
+	var env responseEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if env.Error != nil {
+		t.Errorf("Error = %v, want nil", env.Error)
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"plain error", errors.New("boom"), 500, "internal"},
+		{"unauthenticated", errs.NewUnauthorizedError(errors.New("bad credentials")), 401, "unauthorized"},
+		{"not exist", errs.E(errs.NotExist, errors.New("movie not found")), 404, "not_found"},
+		{"validation", errs.E(errs.Validation, errors.New("extl_id required")), 400, "validation_failed"},
+		{"too many requests", errs.E(errs.TooManyRequests, errors.New("rate limit exceeded")), 429, "too_many_requests"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			lgr := zerolog.Nop()
+
+			respondError(w, lgr, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var env responseEnvelope
+			if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if env.Error == nil {
+				t.Fatal("Error = nil, want non-nil")
+			}
+			if env.Error.Code != tt.wantCode {
+				t.Errorf("Error.Code = %s, want %s", env.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+	"github.com/gilcrest/go-api-basic/ratelimit"
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+// Server holds every dependency an HTTP handler needs and owns the
+// Router handlers are registered against. NewServer wires every route
+// before a caller has had a chance to populate the Server's other
+// fields, so the registered handlers (authenticated and RateLimit in
+// particular) must read those fields at request time rather than
+// capturing them at registration time; callers are free to set
+// Authenticator, RateLimiter, and the services in any order after
+// NewServer returns, as long as it's before the Server serves traffic.
+type Server struct {
+	Router *mux.Router
+
+	Authenticator auth.Authenticator
+	Authorizer    auth.CasbinAuthorizer
+	RateLimiter   ratelimit.Limiter
+
+	CreateMovieService service.CreateMovieService
+	UpdateMovieService service.UpdateMovieService
+	DeleteMovieService service.DeleteMovieService
+	FindMovieService   service.FindMovieService
+	LoggerService      service.LoggerService
+	PingService        service.PingService
+	JWTIssuerService   service.JWTIssuer
+	ACLService         service.ACLService
+	OAuthService       service.OAuthService
+	MovieEventBus      service.MovieEventBus
+}
+
+// NewServer returns a Server with Router initialized and every route
+// registered against it. Callers still need to populate the Server's
+// other fields (services, Authenticator, ...) before it can actually
+// serve a request.
+func NewServer() *Server {
+	s := &Server{Router: mux.NewRouter()}
+	s.routes()
+	return s
+}
+
+// authenticated wraps h with the Authenticate and RateLimit
+// middleware, in the order RateLimit's doc comment requires
+// (Authenticate first, so the rate limit key can be the authenticated
+// user's email).
+func (s *Server) authenticated(h http.HandlerFunc) http.Handler {
+	return s.Authenticate(s.RateLimit(h))
+}
+
+// authorized wraps h the same way authenticated does, and additionally
+// requires the caller to have permission, via s.Authorizer, to perform
+// the request's method against the request's actual path (see
+// Authorize). Use this instead of authenticated for every route the
+// Casbin RBAC/scope model is meant to cover.
+func (s *Server) authorized(h http.HandlerFunc) http.Handler {
+	return s.Authenticate(s.RateLimit(s.Authorize(h)))
+}
+
+// routes registers every handler in the app package against Router.
+func (s *Server) routes() {
+	r := s.Router
+
+	r.Handle("/api/v1/movies", s.authorized(s.handleMovieCreate)).Methods(http.MethodPost)
+	r.Handle("/api/v1/movies", s.authorized(s.handleFindAllMovies)).Methods(http.MethodGet)
+	r.Handle("/api/v1/movies/events", s.authorized(s.handleMovieEvents)).Methods(http.MethodGet)
+	r.Handle("/api/v1/movies/{extlID}", s.authorized(s.handleFindMovieByID)).Methods(http.MethodGet)
+	r.Handle("/api/v1/movies/{extlID}", s.authorized(s.handleMovieUpdate)).Methods(http.MethodPut)
+	r.Handle("/api/v1/movies/{extlID}", s.authorized(s.handleMovieDelete)).Methods(http.MethodDelete)
+
+	// /api/v1/acl administers the Casbin policy itself and goes through
+	// Authorize like any other resource: listing needs read permission
+	// on this object, adding and deleting rules need write permission.
+	r.Handle("/api/v1/acl", s.authorized(s.handleACLFindAll)).Methods(http.MethodGet)
+	r.Handle("/api/v1/acl", s.authorized(s.handleACLCreate)).Methods(http.MethodPost)
+	r.Handle("/api/v1/acl", s.authorized(s.handleACLDelete)).Methods(http.MethodDelete)
+
+	r.Handle("/api/v1/token", s.authenticated(s.handleTokenCreate)).Methods(http.MethodPost)
+
+	r.Handle("/logger", s.authorized(s.handleLoggerRead)).Methods(http.MethodGet)
+	r.Handle("/logger", s.authorized(s.handleLoggerUpdate)).Methods(http.MethodPut)
+
+	// /ping has no Authenticate middleware; RateLimit falls back to
+	// the caller's remote IP per its own doc comment.
+	r.Handle("/ping", s.RateLimit(http.HandlerFunc(s.handlePing))).Methods(http.MethodGet)
+
+	// /oauth/authorize goes through Authenticate (and RateLimit) to
+	// identify the session user. The other OAuth2 endpoints
+	// authenticate the registered client by its own credentials
+	// rather than going through the Authenticate middleware.
+	r.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, req *http.Request) {
+		s.authenticated(s.handleOAuthAuthorize).ServeHTTP(w, req)
+	}).Methods(http.MethodGet)
+	r.HandleFunc("/oauth/token", s.handleOAuthToken).Methods(http.MethodPost)
+	r.HandleFunc("/oauth/revoke", s.handleOAuthRevoke).Methods(http.MethodPost)
+	r.HandleFunc("/oauth/introspect", s.handleOAuthIntrospect).Methods(http.MethodPost)
+}
+
+// ServeHTTP lets Server itself be used as the root http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Router.ServeHTTP(w, r)
+}
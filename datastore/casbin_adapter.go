@@ -0,0 +1,175 @@
+package datastore
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/casbin/casbin/persist"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+// casbinRuleTable stores casbin p and g lines in the same column
+// layout casbin's file adapter uses: ptype, v0, v1, v2, v3, v4, v5
+const casbinRuleTable = "casbin_rule"
+
+// PostgresCasbinAdapter is a casbin persist.Adapter backed by
+// Postgres, replacing the file adapter so policy can be administered
+// at runtime via the /api/v1/acl endpoints rather than a redeploy.
+type PostgresCasbinAdapter struct {
+	Pool *pgxpool.Pool
+}
+
+// NewPostgresCasbinAdapter is an initializer for PostgresCasbinAdapter
+func NewPostgresCasbinAdapter(pool *pgxpool.Pool) *PostgresCasbinAdapter {
+	return &PostgresCasbinAdapter{Pool: pool}
+}
+
+// LoadPolicy loads every p and g line in casbin_rule into model
+func (a *PostgresCasbinAdapter) LoadPolicy(model persist.Model) error {
+	ctx := context.Background()
+
+	rows, err := a.Pool.Query(ctx, "select ptype, v0, v1, v2, v3, v4, v5 from "+casbinRuleTable)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ptype string
+		var v0, v1, v2, v3, v4, v5 *string
+		if err := rows.Scan(&ptype, &v0, &v1, &v2, &v3, &v4, &v5); err != nil {
+			return errs.E(errs.Database, err)
+		}
+
+		persist.LoadPolicyLine(policyLine(ptype, v0, v1, v2, v3, v4, v5), model)
+	}
+
+	return rows.Err()
+}
+
+// SavePolicy replaces every row in casbin_rule with the rules
+// currently held in model
+func (a *PostgresCasbinAdapter) SavePolicy(model persist.Model) error {
+	ctx := context.Background()
+
+	tx, err := a.Pool.Begin(ctx)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "delete from "+casbinRuleTable); err != nil {
+		return errs.E(errs.Database, err)
+	}
+
+	for ptype, ast := range model["p"] {
+		for _, rule := range ast.Policy {
+			if err := insertRule(ctx, tx, ptype, rule); err != nil {
+				return err
+			}
+		}
+	}
+	for ptype, ast := range model["g"] {
+		for _, rule := range ast.Policy {
+			if err := insertRule(ctx, tx, ptype, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// AddPolicy inserts a single p or g line into casbin_rule
+func (a *PostgresCasbinAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return insertRule(context.Background(), a.Pool, ptype, rule)
+}
+
+// RemovePolicy deletes a single p or g line matching ptype and rule
+// exactly
+func (a *PostgresCasbinAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	where, args := ruleWhereClause(ptype, rule)
+	_, err := a.Pool.Exec(context.Background(), "delete from "+casbinRuleTable+" where "+where, args...)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// RemoveFilteredPolicy deletes every p or g line matching ptype whose
+// fields, starting at fieldIndex, equal fieldValues
+func (a *PostgresCasbinAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	rule := make([]string, fieldIndex+len(fieldValues))
+	for i, v := range fieldValues {
+		rule[fieldIndex+i] = v
+	}
+
+	where, args := ruleWhereClause(ptype, rule)
+	_, err := a.Pool.Exec(context.Background(), "delete from "+casbinRuleTable+" where "+where, args...)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, letting
+// insertRule run either standalone or inside SavePolicy's transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgx.CommandTag, error)
+}
+
+func insertRule(ctx context.Context, q querier, ptype string, rule []string) error {
+	v := make([]*string, 6)
+	for i, val := range rule {
+		if i >= len(v) {
+			break
+		}
+		val := val
+		v[i] = &val
+	}
+
+	_, err := q.Exec(ctx,
+		"insert into "+casbinRuleTable+" (ptype, v0, v1, v2, v3, v4, v5) values ($1, $2, $3, $4, $5, $6, $7)",
+		ptype, v[0], v[1], v[2], v[3], v[4], v[5])
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// policyLine renders a casbin policy line (ptype, v0..v5) the same
+// way casbin's file adapter does, stopping at the first nil value
+func policyLine(ptype string, v ...*string) string {
+	line := ptype
+	for _, p := range v {
+		if p == nil {
+			break
+		}
+		line += ", " + *p
+	}
+	return line
+}
+
+// ruleWhereClause builds a `ptype = $1 and v0 = $2 and ...` clause
+// matching rule, skipping empty fields
+func ruleWhereClause(ptype string, rule []string) (string, []interface{}) {
+	where := "ptype = $1"
+	args := []interface{}{ptype}
+
+	columns := []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+	for i, val := range rule {
+		if i >= len(columns) || val == "" {
+			continue
+		}
+		args = append(args, val)
+		where += " and " + columns[i] + " = $" + strconv.Itoa(len(args))
+	}
+
+	return where, args
+}
@@ -0,0 +1,73 @@
+package datastore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/casbin/casbin/model"
+	"github.com/rs/zerolog"
+
+	"github.com/gilcrest/go-api-basic/domain/logger"
+)
+
+// newCasbinTestModel loads the repo's real casbin model definition so
+// LoadPolicy has somewhere to put the rules it reads back.
+func newCasbinTestModel(t *testing.T) model.Model {
+	t.Helper()
+
+	m, err := model.NewModelFromFile(filepath.Join("..", "domain", "auth", "casbin_model.conf"))
+	if err != nil {
+		t.Fatalf("model.NewModelFromFile() error = %v", err)
+	}
+	return m
+}
+
+func TestPostgresCasbinAdapter_AddLoadRemovePolicy(t *testing.T) {
+	ctx := context.Background()
+	lgr := logger.NewLogger(os.Stdout, zerolog.DebugLevel, true)
+	dsn := NewPostgreSQLDSN("localhost", "go_api_basic", "postgres", "", 5432)
+
+	pool, cleanup, err := NewPostgreSQLPool(ctx, dsn, lgr)
+	t.Cleanup(cleanup)
+	if err != nil {
+		t.Fatalf("NewPostgreSQLPool() error = %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, "delete from "+casbinRuleTable); err != nil {
+		t.Fatalf("delete from %s error = %v", casbinRuleTable, err)
+	}
+
+	a := NewPostgresCasbinAdapter(pool)
+
+	if err := a.AddPolicy("p", "p", []string{"admin", "/api/v1/movies", "write"}); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+	if err := a.AddPolicy("g", "g", []string{"otto.maddox711@gmail.com", "admin"}); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+
+	model := newCasbinTestModel(t)
+	if err := a.LoadPolicy(model); err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if len(model["p"]["p"].Policy) != 1 {
+		t.Errorf("LoadPolicy() p rules = %v, want 1", model["p"]["p"].Policy)
+	}
+	if len(model["g"]["g"].Policy) != 1 {
+		t.Errorf("LoadPolicy() g rules = %v, want 1", model["g"]["g"].Policy)
+	}
+
+	if err := a.RemovePolicy("p", "p", []string{"admin", "/api/v1/movies", "write"}); err != nil {
+		t.Fatalf("RemovePolicy() error = %v", err)
+	}
+
+	model = newCasbinTestModel(t)
+	if err := a.LoadPolicy(model); err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if len(model["p"]["p"].Policy) != 0 {
+		t.Errorf("LoadPolicy() after RemovePolicy() p rules = %v, want 0", model["p"]["p"].Policy)
+	}
+}
@@ -0,0 +1,91 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+// movieEventChannel is the Postgres NOTIFY channel movie change
+// events are published on
+const movieEventChannel = "movie_events"
+
+// PostgresMovieEventBus is a service.MovieEventBus backed by Postgres
+// LISTEN/NOTIFY, so an event published by the instance that handled a
+// mutation is observed by every instance subscribed to
+// movieEventChannel, not just that one.
+type PostgresMovieEventBus struct {
+	Pool *pgxpool.Pool
+}
+
+// NewPostgresMovieEventBus is an initializer for
+// PostgresMovieEventBus
+func NewPostgresMovieEventBus(pool *pgxpool.Pool) PostgresMovieEventBus {
+	return PostgresMovieEventBus{Pool: pool}
+}
+
+// Publish sends event to every listener on movieEventChannel via
+// pg_notify
+func (b PostgresMovieEventBus) Publish(ctx context.Context, event service.MovieEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errs.E(errs.Internal, err)
+	}
+
+	if _, err := b.Pool.Exec(ctx, "select pg_notify($1, $2)", movieEventChannel, string(payload)); err != nil {
+		return errs.E(errs.Database, err)
+	}
+
+	return nil
+}
+
+// Subscribe opens a dedicated connection LISTENing on
+// movieEventChannel and streams decoded events until ctx is done or
+// the returned unsubscribe func is called.
+func (b PostgresMovieEventBus) Subscribe(ctx context.Context) (<-chan service.MovieEvent, func(), error) {
+	conn, err := b.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, nil, errs.E(errs.Database, err)
+	}
+
+	if _, err := conn.Exec(ctx, "listen "+movieEventChannel); err != nil {
+		conn.Release()
+		return nil, nil, errs.E(errs.Database, err)
+	}
+
+	events := make(chan service.MovieEvent, 16)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(events)
+		defer conn.Release()
+
+		for {
+			notification, err := conn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				return
+			}
+
+			var event service.MovieEvent
+			if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	unsubscribe := func() {
+		close(done)
+	}
+
+	return events, unsubscribe, nil
+}
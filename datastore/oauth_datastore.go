@@ -0,0 +1,117 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/service"
+)
+
+// OAuthDatastore persists OAuth2 clients, authorization codes and
+// tokens in Postgres. It implements service.OAuthClientStore and
+// service.OAuthTokenStore.
+type OAuthDatastore struct {
+	Pool *pgxpool.Pool
+}
+
+// NewOAuthDatastore is an initializer for OAuthDatastore
+func NewOAuthDatastore(pool *pgxpool.Pool) OAuthDatastore {
+	return OAuthDatastore{Pool: pool}
+}
+
+// FindByClientID looks up a registered OAuth2 client by its ID. The
+// client secret is stored only as its sha256 hash
+// (service.HashClientSecret), never in plaintext.
+func (ds OAuthDatastore) FindByClientID(ctx context.Context, clientID string) (service.OAuthClient, error) {
+	var c service.OAuthClient
+	row := ds.Pool.QueryRow(ctx,
+		"select client_id, client_secret_hash, redirect_uri, allowed_scopes from oauth_client where client_id = $1",
+		clientID)
+	if err := row.Scan(&c.ClientID, &c.ClientSecretHash, &c.RedirectURI, &c.AllowedScopes); err != nil {
+		return service.OAuthClient{}, errs.E(errs.NotExist, err)
+	}
+	return c, nil
+}
+
+// SaveCode persists an authorization code
+func (ds OAuthDatastore) SaveCode(ctx context.Context, code service.AuthorizationCode) error {
+	_, err := ds.Pool.Exec(ctx,
+		`insert into oauth_authorization_code (code, client_id, user_id, redirect_uri, scopes, expires_at)
+		 values ($1, $2, $3, $4, $5, $6)`,
+		code.Code, code.ClientID, code.UserID, code.RedirectURI, code.Scopes, code.ExpiresAt)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// FindCode looks up an authorization code
+func (ds OAuthDatastore) FindCode(ctx context.Context, code string) (service.AuthorizationCode, error) {
+	var ac service.AuthorizationCode
+	row := ds.Pool.QueryRow(ctx,
+		"select code, client_id, user_id, redirect_uri, scopes, expires_at from oauth_authorization_code where code = $1",
+		code)
+	if err := row.Scan(&ac.Code, &ac.ClientID, &ac.UserID, &ac.RedirectURI, &ac.Scopes, &ac.ExpiresAt); err != nil {
+		return service.AuthorizationCode{}, errs.E(errs.NotExist, err)
+	}
+	return ac, nil
+}
+
+// DeleteCode removes an authorization code once it has been exchanged
+func (ds OAuthDatastore) DeleteCode(ctx context.Context, code string) error {
+	_, err := ds.Pool.Exec(ctx, "delete from oauth_authorization_code where code = $1", code)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// SaveToken persists an issued access/refresh token pair, upserting by
+// refresh_token so Refresh can rotate the access token in place
+func (ds OAuthDatastore) SaveToken(ctx context.Context, token service.OAuthToken) error {
+	_, err := ds.Pool.Exec(ctx,
+		`insert into oauth_token (access_token, refresh_token, client_id, user_id, scopes, expires_at, revoked)
+		 values ($1, $2, $3, $4, $5, $6, false)
+		 on conflict (refresh_token) do update
+		   set access_token = excluded.access_token, expires_at = excluded.expires_at`,
+		token.AccessToken, token.RefreshToken, token.ClientID, token.UserID, token.Scopes, token.ExpiresAt)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// FindByAccessToken looks up a token by its access token value
+func (ds OAuthDatastore) FindByAccessToken(ctx context.Context, accessToken string) (service.OAuthToken, error) {
+	return ds.findToken(ctx, "access_token", accessToken)
+}
+
+// FindByRefreshToken looks up a token by its refresh token value
+func (ds OAuthDatastore) FindByRefreshToken(ctx context.Context, refreshToken string) (service.OAuthToken, error) {
+	return ds.findToken(ctx, "refresh_token", refreshToken)
+}
+
+// findToken looks up a token by column, which must be a trusted,
+// internally-defined column name ("access_token" or "refresh_token"),
+// never a caller-supplied value.
+func (ds OAuthDatastore) findToken(ctx context.Context, column, value string) (service.OAuthToken, error) {
+	var t service.OAuthToken
+	row := ds.Pool.QueryRow(ctx,
+		"select access_token, refresh_token, client_id, user_id, scopes, expires_at, revoked from oauth_token where "+column+" = $1",
+		value)
+	if err := row.Scan(&t.AccessToken, &t.RefreshToken, &t.ClientID, &t.UserID, &t.Scopes, &t.ExpiresAt, &t.Revoked); err != nil {
+		return service.OAuthToken{}, errs.E(errs.NotExist, err)
+	}
+	return t, nil
+}
+
+// Revoke marks a token as revoked, per RFC 7009
+func (ds OAuthDatastore) Revoke(ctx context.Context, accessToken string) error {
+	_, err := ds.Pool.Exec(ctx, "update oauth_token set revoked = true where access_token = $1", accessToken)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
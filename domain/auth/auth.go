@@ -58,6 +58,11 @@ func CtxWithRealm(ctx context.Context, realm WWWAuthenticateRealm) context.Conte
 type AccessToken struct {
 	Token     string
 	TokenType string
+	// Scopes are the OAuth2 scopes (e.g. "movies:read") the token was
+	// issued with, if any. Empty for Google and self-issued JWT
+	// tokens, which are scoped only by the underlying user's RBAC
+	// role rather than by token.
+	Scopes []string
 }
 
 // NewAccessToken is an initializer for AccessToken
@@ -68,6 +73,17 @@ func NewAccessToken(token, tokenType string) AccessToken {
 	}
 }
 
+// NewScopedAccessToken is an initializer for AccessToken for tokens
+// issued by the OAuth2 subsystem (service.OAuthService), which are
+// limited to scopes.
+func NewScopedAccessToken(token, tokenType string, scopes []string) AccessToken {
+	return AccessToken{
+		Token:     token,
+		TokenType: tokenType,
+		Scopes:    scopes,
+	}
+}
+
 // NewGoogleOauth2Token returns a Google Oauth2 token given an AccessToken
 func (at AccessToken) NewGoogleOauth2Token() *oauth2.Token {
 	return &oauth2.Token{AccessToken: at.Token, TokenType: at.TokenType}
@@ -100,29 +116,34 @@ type CasbinAuthorizer  struct {
 // Authorize ensures that a subject (user.User) can perform a
 // particular action on an object. e.g. subject otto.maddox711@gmail.com
 // can read (GET) the object (resource) at the /api/v1/movies path.
-// Casbin is setup to use an RBAC (Role-Based Access Control) model
+// Casbin is setup to use an RBAC (Role-Based Access Control) model,
+// with the model's matcher using keyMatch2 so policy rules (e.g.
+// `/api/v1/movies/*`) decide which paths an object belongs to rather
+// than this method collapsing paths itself.
 // Users with the admin role can *write* (GET, PUT, POST, DELETE).
 // Users with the user role can only *read* (GET)
-func (a CasbinAuthorizer) Authorize(lgr zerolog.Logger, sub user.User, obj string, act string) error {
-
-	const (
-		moviesPath string = "/api/v1/movies"
-		loggerPath string = "/api/v1/logger"
-	)
-
-	if strings.HasPrefix(obj, moviesPath) {
-		obj = moviesPath
-	} else if strings.HasPrefix(obj, loggerPath) {
-		obj = loggerPath
-	} else {
-		return errs.NewUnauthorizedError(errors.New(fmt.Sprintf("user %s does not have %s permission for %s", sub.Email, act, obj)))
-	}
-
-	if (act == http.MethodGet) {
+//
+// If ctx carries an AccessToken scoped by the OAuth2 subsystem (see
+// service.OAuthService), its scopes must also be a superset of the
+// scope required for act on obj (e.g. "movies:read"). Tokens with no
+// scopes (Google, self-issued JWT) rely solely on the RBAC check
+// above.
+func (a CasbinAuthorizer) Authorize(ctx context.Context, lgr zerolog.Logger, sub user.User, obj string, act string) error {
+
+	if act == http.MethodGet {
 		act = "read"
 	} else {
 		act = "write"
 	}
+
+	if at, ok := AccessTokenFromCtx(ctx); ok && len(at.Scopes) > 0 {
+		want := requiredScope(obj, act)
+		if !scopeGranted(at.Scopes, want) {
+			lgr.Info().Str("sub", sub.Email).Str("obj", obj).Str("act", act).Msgf("token scope does not include %s", want)
+			return errs.NewUnauthorizedError(errors.New(fmt.Sprintf("token scope does not include %s", want)))
+		}
+	}
+
 	authorized := a.Enforcer.Enforce(sub.Email, obj, act)
 	if authorized {
 		lgr.Debug().Str("sub", sub.Email).Str("obj", obj).Str("act", act).Msgf("Authorized (sub: %s, obj: %s, act: %s)", sub.Email, obj, act)
@@ -140,6 +161,29 @@ func (a CasbinAuthorizer) Authorize(lgr zerolog.Logger, sub user.User, obj strin
 	return errs.NewUnauthorizedError(errors.New(fmt.Sprintf("user %s does not have %s permission for %s", sub.Email, act, obj)))
 }
 
+// scopeGranted reports whether want is present in scopes
+func scopeGranted(scopes []string, want string) bool {
+	for _, scope := range scopes {
+		if scope == want {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredScope derives the OAuth2 scope string required to perform
+// act ("read" or "write", as mapped by Authorize) on obj, e.g.
+// "movies:read" for a GET on /api/v1/movies
+func requiredScope(obj, act string) string {
+	resource := strings.TrimPrefix(obj, "/api/v1/")
+	resource = strings.SplitN(resource, "/", 2)[0]
+
+	if act == "read" {
+		return resource + ":read"
+	}
+	return resource + ":write"
+}
+
 // AccessControlList (ACL) describes permissions for a given object
 type AccessControlList struct {
 	Subject string
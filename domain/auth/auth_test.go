@@ -3,9 +3,13 @@ package auth
 import (
 	"context"
 	"net/http"
+	"os"
 	"reflect"
 	"testing"
 
+	"github.com/casbin/casbin"
+	"github.com/rs/zerolog"
+
 	"github.com/gilcrest/go-api-basic/domain/user/usertest"
 
 	"github.com/gilcrest/go-api-basic/domain/user"
@@ -171,3 +175,48 @@ func TestFromRequest(t *testing.T) {
 		})
 	}
 }
+
+// newTestEnforcer returns a casbin.Enforcer using the repo's real
+// model, with sub granted read access to obj via an RBAC role.
+func newTestEnforcer(t *testing.T, sub, obj string) *casbin.Enforcer {
+	t.Helper()
+
+	policyFile, err := os.CreateTemp(t.TempDir(), "casbin-policy-*.csv")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	defer policyFile.Close()
+
+	policy := "p, user, " + obj + ", read\n" + "g, " + sub + ", user\n"
+	if _, err := policyFile.WriteString(policy); err != nil {
+		t.Fatalf("WriteString() error = %v", err)
+	}
+
+	return casbin.NewEnforcer("casbin_model.conf", policyFile.Name())
+}
+
+func TestCasbinAuthorizer_Authorize_ScopedAccessToken(t *testing.T) {
+	u := usertest.NewUser(t)
+	obj := "/api/v1/movies"
+	e := newTestEnforcer(t, u.Email, obj)
+	a := CasbinAuthorizer{Enforcer: e}
+	lgr := zerolog.Nop()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{"no access token on context", context.Background(), false},
+		{"access token with no scopes", CtxWithAccessToken(context.Background(), NewAccessToken("tok", BearerTokenType)), false},
+		{"scoped token with the required scope", CtxWithAccessToken(context.Background(), NewScopedAccessToken("tok", BearerTokenType, []string{"movies:read"})), false},
+		{"scoped token missing the required scope", CtxWithAccessToken(context.Background(), NewScopedAccessToken("tok", BearerTokenType, []string{"movies:write"})), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := a.Authorize(tt.ctx, lgr, u, obj, http.MethodGet); (err != nil) != tt.wantErr {
+				t.Errorf("Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// Authenticator authenticates an incoming AccessToken against an
+// identity provider and returns the user.User it represents, along
+// with any OAuth2 scopes (e.g. "movies:read") the token is limited
+// to. The server's middleware invokes whichever Authenticator the DI
+// graph provides, so operators can swap identity providers (Google,
+// OIDC, Keystone, self-issued JWT, service.OAuthTokenAuthenticator,
+// ...) via configuration, not code changes.
+type Authenticator interface {
+	// Authenticate returns scopes == nil for tokens scoped only by
+	// the returned user's RBAC role (Google, OIDC, Keystone,
+	// self-issued JWT); CasbinAuthorizer skips its scope check in
+	// that case.
+	Authenticate(ctx context.Context, at AccessToken) (u user.User, scopes []string, err error)
+	// Scheme is the auth-scheme advertised in the WWW-Authenticate
+	// challenge header on a 401 response, e.g. "Bearer"
+	Scheme() string
+}
+
+// Challenge builds the WWW-Authenticate header value for a, scoped to
+// realm, so a 401 response correctly advertises the active scheme.
+func Challenge(a Authenticator, realm WWWAuthenticateRealm) string {
+	return fmt.Sprintf(`%s realm="%s"`, a.Scheme(), realm)
+}
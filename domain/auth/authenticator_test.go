@@ -0,0 +1,12 @@
+package auth
+
+import "testing"
+
+func TestChallenge(t *testing.T) {
+	a := NewJWTAuthenticator(nil, "go-api-basic", "go-api-basic-clients")
+
+	want := `Bearer realm="go-api-basic"`
+	if got := Challenge(a, DefaultRealm); got != want {
+		t.Errorf("Challenge() = %v, want %v", got, want)
+	}
+}
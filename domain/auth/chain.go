@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// AuthenticatorChain tries each Authenticator in order, returning the
+// result of the first one that successfully authenticates at. It lets
+// a single deployment accept more than one kind of Bearer token at
+// once, e.g. self-issued JWTs from regular users alongside scoped
+// tokens issued to third-party clients by service.OAuthService.
+type AuthenticatorChain []Authenticator
+
+// Authenticate tries each Authenticator in c in order, returning the
+// first one that succeeds, or the last error seen if none do.
+func (c AuthenticatorChain) Authenticate(ctx context.Context, at AccessToken) (user.User, []string, error) {
+	if len(c) == 0 {
+		return user.User{}, nil, errs.NewUnauthorizedError(errors.New("no authenticators configured"))
+	}
+
+	var err error
+	for _, a := range c {
+		var u user.User
+		var scopes []string
+		u, scopes, err = a.Authenticate(ctx, at)
+		if err == nil {
+			return u, scopes, nil
+		}
+	}
+	return user.User{}, nil, err
+}
+
+// Scheme returns the first Authenticator's scheme, since every
+// Authenticator in this package challenges with "Bearer".
+func (c AuthenticatorChain) Scheme() string {
+	if len(c) == 0 {
+		return BearerTokenType
+	}
+	return c[0].Scheme()
+}
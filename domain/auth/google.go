@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// googleUserinfoEndpoint is Google's Oauth2 v2 userinfo endpoint, used
+// to resolve an access token to the Google account that owns it
+const googleUserinfoEndpoint = "https://www.googleapis.com/oauth2/v2/userinfo"
+
+// GoogleAuthenticator authenticates requests by calling Google's
+// userinfo endpoint with the caller's access token
+type GoogleAuthenticator struct {
+	HTTPClient *http.Client
+
+	// userinfoEndpoint defaults to googleUserinfoEndpoint; overridable
+	// so tests can point it at an httptest server instead of Google.
+	userinfoEndpoint string
+}
+
+// NewGoogleAuthenticator is an initializer for GoogleAuthenticator. A
+// nil httpClient defaults to http.DefaultClient.
+func NewGoogleAuthenticator(httpClient *http.Client) GoogleAuthenticator {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return GoogleAuthenticator{HTTPClient: httpClient, userinfoEndpoint: googleUserinfoEndpoint}
+}
+
+// Authenticate exchanges at for the Google account it belongs to
+func (a GoogleAuthenticator) Authenticate(ctx context.Context, at AccessToken) (user.User, []string, error) {
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(at.NewGoogleOauth2Token()))
+
+	endpoint := a.userinfoEndpoint
+	if endpoint == "" {
+		endpoint = googleUserinfoEndpoint
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return user.User{}, nil, errs.E(errs.Internal, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return user.User{}, nil, errs.NewUnauthorizedError(fmt.Errorf("google userinfo: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return user.User{}, nil, errs.NewUnauthorizedError(fmt.Errorf("google userinfo returned status %d", resp.StatusCode))
+	}
+
+	var info struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return user.User{}, nil, errs.E(errs.Internal, err)
+	}
+
+	return user.User{Email: info.Email}, nil, nil
+}
+
+// Scheme returns the auth-scheme advertised in the WWW-Authenticate
+// challenge header when authentication fails
+func (a GoogleAuthenticator) Scheme() string {
+	return BearerTokenType
+}
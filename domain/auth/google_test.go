@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGoogleAuthenticator_Authenticate(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		email      string
+		wantErr    bool
+	}{
+		{"typical", http.StatusOK, "otto.maddox711@gmail.com", false},
+		{"google rejects token", http.StatusUnauthorized, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer abcdef123" {
+					t.Errorf("Authorization header = %s, want Bearer abcdef123", got)
+				}
+
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(struct {
+						Email string `json:"email"`
+					}{Email: tt.email})
+				}
+			}))
+			defer srv.Close()
+
+			a := NewGoogleAuthenticator(nil)
+			a.userinfoEndpoint = srv.URL
+			at := AccessToken{Token: "abcdef123", TokenType: BearerTokenType}
+
+			u, scopes, err := a.Authenticate(context.Background(), at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if u.Email != tt.email {
+				t.Errorf("Authenticate() email = %s, want %s", u.Email, tt.email)
+			}
+			if scopes != nil {
+				t.Errorf("Authenticate() scopes = %v, want nil", scopes)
+			}
+		})
+	}
+}
+
+func TestGoogleAuthenticator_Scheme(t *testing.T) {
+	a := NewGoogleAuthenticator(nil)
+	if got := a.Scheme(); got != BearerTokenType {
+		t.Errorf("Scheme() = %s, want %s", got, BearerTokenType)
+	}
+}
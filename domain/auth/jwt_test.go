@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	secret := []byte("test-signing-key")
+	keyFunc := func(t *jwt.Token) (interface{}, error) { return secret, nil }
+	a := NewJWTAuthenticator(keyFunc, "go-api-basic", "go-api-basic-clients")
+
+	sign := func(claims JWTClaims) string {
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return tok
+	}
+
+	now := time.Now()
+	validClaims := JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "go-api-basic",
+			Audience:  jwt.ClaimStrings{"go-api-basic-clients"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+		},
+		Email: "otto.maddox711@gmail.com",
+	}
+
+	expiredClaims := validClaims
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+
+	wrongAudienceClaims := validClaims
+	wrongAudienceClaims.Audience = jwt.ClaimStrings{"someone-else"}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"typical", sign(validClaims), false},
+		{"expired", sign(expiredClaims), true},
+		{"wrong audience", sign(wrongAudienceClaims), true},
+		{"malformed", "not-a-jwt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := NewAccessToken(tt.token, BearerTokenType)
+			u, _, err := a.Authenticate(context.Background(), at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && u.Email != validClaims.Email {
+				t.Errorf("Authenticate() email = %v, want %v", u.Email, validClaims.Email)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// KeystoneAuthenticator authenticates requests against an OpenStack
+// Keystone identity service by validating the bearer token via
+// Keystone's token validation API.
+type KeystoneAuthenticator struct {
+	// Endpoint is the base URL of the Keystone identity service, e.g.
+	// https://keystone.example.com
+	Endpoint string
+	// AdminToken authenticates this service to Keystone's token
+	// validation API
+	AdminToken string
+	HTTPClient *http.Client
+}
+
+// NewKeystoneAuthenticator is an initializer for KeystoneAuthenticator.
+// A nil httpClient defaults to http.DefaultClient.
+func NewKeystoneAuthenticator(endpoint, adminToken string, httpClient *http.Client) KeystoneAuthenticator {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return KeystoneAuthenticator{Endpoint: endpoint, AdminToken: adminToken, HTTPClient: httpClient}
+}
+
+type keystoneTokenResponse struct {
+	Token struct {
+		User struct {
+			Email string `json:"email"`
+		} `json:"user"`
+	} `json:"token"`
+}
+
+// Authenticate validates at against Keystone's /v3/auth/tokens
+// endpoint and maps the returned user onto a user.User. It always
+// returns nil scopes: the scopes slot is the OAuth2 capability-scope
+// channel CasbinAuthorizer.Authorize treats as a superset requirement
+// (see requiredScope), and Keystone role names (e.g. "admin",
+// "member") are not "<resource>:<act>" strings, so returning them
+// there makes every RBAC-protected route unauthorized for every
+// Keystone-authenticated caller. Keystone callers are scoped solely by
+// the returned user's RBAC role in the Postgres-backed policy, the
+// same as Google, OIDC, and self-issued JWT callers.
+func (a KeystoneAuthenticator) Authenticate(ctx context.Context, at AccessToken) (user.User, []string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.Endpoint+"/v3/auth/tokens", nil)
+	if err != nil {
+		return user.User{}, nil, errs.E(errs.Internal, err)
+	}
+	req.Header.Set("X-Auth-Token", a.AdminToken)
+	req.Header.Set("X-Subject-Token", at.Token)
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return user.User{}, nil, errs.E(errs.Internal, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return user.User{}, nil, errs.NewUnauthorizedError(fmt.Errorf("keystone token validation returned status %d", resp.StatusCode))
+	}
+
+	var tr keystoneTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return user.User{}, nil, errs.E(errs.Internal, err)
+	}
+
+	return user.User{Email: tr.Token.User.Email}, nil, nil
+}
+
+// Scheme returns the auth-scheme advertised in the WWW-Authenticate
+// challenge header when authentication fails
+func (a KeystoneAuthenticator) Scheme() string {
+	return BearerTokenType
+}
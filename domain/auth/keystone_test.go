@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeystoneAuthenticator_Authenticate(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		email      string
+		wantErr    bool
+	}{
+		{"typical", http.StatusOK, "otto.maddox711@gmail.com", false},
+		{"keystone rejects token", http.StatusUnauthorized, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/auth/tokens" {
+					t.Errorf("request path = %s, want /v3/auth/tokens", r.URL.Path)
+				}
+				if got := r.Header.Get("X-Subject-Token"); got != "abcdef123" {
+					t.Errorf("X-Subject-Token = %s, want abcdef123", got)
+				}
+
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode == http.StatusOK {
+					var tr keystoneTokenResponse
+					tr.Token.User.Email = tt.email
+					_ = json.NewEncoder(w).Encode(tr)
+				}
+			}))
+			defer srv.Close()
+
+			a := NewKeystoneAuthenticator(srv.URL, "admin-token", nil)
+			at := AccessToken{Token: "abcdef123", TokenType: BearerTokenType}
+
+			u, scopes, err := a.Authenticate(context.Background(), at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if u.Email != tt.email {
+				t.Errorf("Authenticate() email = %s, want %s", u.Email, tt.email)
+			}
+			if scopes != nil {
+				t.Errorf("Authenticate() scopes = %v, want nil", scopes)
+			}
+		})
+	}
+}
+
+func TestKeystoneAuthenticator_Scheme(t *testing.T) {
+	a := NewKeystoneAuthenticator("https://keystone.example.com", "admin-token", nil)
+	if got := a.Scheme(); got != BearerTokenType {
+		t.Errorf("Scheme() = %s, want %s", got, BearerTokenType)
+	}
+}
@@ -0,0 +1,83 @@
+// Package errs defines the application's error type, modeled after
+// the upspin.io/errors package: a single Error carries a Kind so
+// callers at the edge (e.g. app.respondError) can map any error
+// returned from deeper in the call stack onto the right HTTP status
+// without needing to know where it originated.
+package errs
+
+import "fmt"
+
+// Kind categorizes an Error so it can be mapped onto a transport-level
+// response (e.g. an HTTP status code) without the caller needing to
+// know anything else about the underlying failure.
+type Kind uint8
+
+const (
+	// Internal is an unexpected, otherwise uncategorized error, e.g.
+	// a failed JSON encode. Maps to a 500 response.
+	Internal Kind = iota
+	// Database is an error returned by the datastore layer, e.g. a
+	// failed query or scan. Maps to a 500 response.
+	Database
+	// Validation is a client error in the shape or content of a
+	// request, e.g. a missing required field. Maps to a 400
+	// response.
+	Validation
+	// NotExist means the requested resource does not exist. Maps to
+	// a 404 response.
+	NotExist
+	// Unauthenticated means the request's credentials were missing
+	// or invalid. Maps to a 401 response.
+	Unauthenticated
+	// TooManyRequests means the caller has exceeded a rate limit.
+	// Maps to a 429 response.
+	TooManyRequests
+)
+
+// Error is the application's error type. Kind categorizes the error
+// for the response layer; Err, when set, is the underlying error
+// being wrapped.
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+// Error implements the error interface, returning the underlying
+// error's message, if any.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return "unknown error"
+}
+
+// Unwrap returns the underlying error, so errors.Is/As can see
+// through an *Error to whatever it wraps.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// E builds an *Error from its arguments. A Kind argument sets the
+// Kind; an error argument sets Err; a string argument is wrapped in
+// an error and sets Err. Arguments are applied in the order given, so
+// a later argument of the same type overrides an earlier one.
+func E(args ...interface{}) *Error {
+	e := &Error{}
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case Kind:
+			e.Kind = v
+		case error:
+			e.Err = v
+		case string:
+			e.Err = fmt.Errorf(v)
+		}
+	}
+	return e
+}
+
+// NewUnauthorizedError is a convenience constructor for an
+// Unauthenticated Error wrapping err.
+func NewUnauthorizedError(err error) *Error {
+	return E(Unauthenticated, err)
+}
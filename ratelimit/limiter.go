@@ -0,0 +1,18 @@
+// Package ratelimit provides pluggable request rate limiting for the
+// app package's RateLimit middleware.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter enforces a rate limit keyed on an arbitrary string,
+// typically an authenticated user's email or a remote IP for
+// unauthenticated routes.
+type Limiter interface {
+	// Allow reports whether a request identified by key may proceed,
+	// along with the limit's capacity, the caller's remaining quota
+	// in the current window, and when that window resets.
+	Allow(ctx context.Context, key string) (allowed bool, limit int, remaining int, reset time.Time, err error)
+}
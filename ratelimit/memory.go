@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// MemoryLimiter is an in-memory, per-process Limiter backed by
+// golang.org/x/time/rate, suitable for a single-instance deployment.
+// Entries idle longer than idleTTL are garbage collected periodically
+// so memory does not grow unbounded as new keys (users, IPs) appear.
+type MemoryLimiter struct {
+	limit   rate.Limit
+	burst   int
+	idleTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+type memoryEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewMemoryLimiter returns a MemoryLimiter that allows burst requests
+// immediately and refills at limit requests per second, evicting
+// entries idle longer than idleTTL.
+func NewMemoryLimiter(limit rate.Limit, burst int, idleTTL time.Duration) *MemoryLimiter {
+	l := &MemoryLimiter{
+		limit:   limit,
+		burst:   burst,
+		idleTTL: idleTTL,
+		entries: make(map[string]*memoryEntry),
+	}
+	go l.gcLoop()
+	return l
+}
+
+// Allow reports whether key may proceed, creating a new token bucket
+// for keys not seen before.
+func (l *MemoryLimiter) Allow(ctx context.Context, key string) (bool, int, int, time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = &memoryEntry{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.entries[key] = e
+	}
+	e.lastSeen = time.Now()
+
+	now := time.Now()
+	r := e.limiter.ReserveN(now, 1)
+	delay := r.Delay()
+	allowed := delay == 0
+	if !allowed {
+		// Give the reservation back so a denied request doesn't also
+		// consume a future token.
+		r.Cancel()
+	}
+
+	remaining := int(e.limiter.Tokens())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	// A denied request resets when its reservation would have been
+	// served; an allowed one when the next token is refilled. Both are
+	// computed in floating point, so an l.limit below 1 token/sec
+	// neither truncates to zero nor divides by zero.
+	reset := now.Add(delay)
+	if allowed && l.limit > 0 {
+		reset = now.Add(time.Duration(float64(time.Second) / float64(l.limit)))
+	}
+
+	return allowed, l.burst, remaining, reset, nil
+}
+
+// gcLoop periodically evicts entries that have been idle longer than
+// idleTTL
+func (l *MemoryLimiter) gcLoop() {
+	ticker := time.NewTicker(l.idleTTL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-l.idleTTL)
+
+		l.mu.Lock()
+		for key, e := range l.entries {
+			if e.lastSeen.Before(cutoff) {
+				delete(l.entries, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
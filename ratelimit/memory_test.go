@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter(rate.Limit(2), 2, time.Minute)
+
+	allowed, limit, remaining, _, err := l.Allow(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Error("Allow() = false on first request, want true (burst available)")
+	}
+	if limit != 2 {
+		t.Errorf("Allow() limit = %d, want 2", limit)
+	}
+	if remaining != 1 {
+		t.Errorf("Allow() remaining = %d, want 1", remaining)
+	}
+
+	allowed, _, _, _, err = l.Allow(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Error("Allow() = false on second request within burst, want true")
+	}
+
+	allowed, _, _, reset, err := l.Allow(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Error("Allow() = true once burst is exhausted, want false")
+	}
+	if !reset.After(time.Now()) {
+		t.Errorf("Allow() reset = %v, want a time in the future", reset)
+	}
+}
+
+// TestMemoryLimiter_Allow_SubOneLimit exercises a rate.Limit below one
+// token per second, which previously panicked with a divide-by-zero
+// in the reset-time computation.
+func TestMemoryLimiter_Allow_SubOneLimit(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter(rate.Limit(0.0028), 1, time.Minute)
+
+	allowed, _, _, reset, err := l.Allow(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Error("Allow() = false on first request, want true (burst available)")
+	}
+	if !reset.After(time.Now()) {
+		t.Errorf("Allow() reset = %v, want a time in the future", reset)
+	}
+}
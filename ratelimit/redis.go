@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisLimiter is a fixed-window Limiter backed by Redis INCR and
+// EXPIRE, giving every application instance a shared view of each
+// key's request count across a multi-instance deployment.
+type RedisLimiter struct {
+	Client *redis.Client
+	Limit  int
+	Window time.Duration
+}
+
+// NewRedisLimiter is an initializer for RedisLimiter
+func NewRedisLimiter(client *redis.Client, limit int, window time.Duration) *RedisLimiter {
+	return &RedisLimiter{Client: client, Limit: limit, Window: window}
+}
+
+// Allow reports whether key may proceed, incrementing its count for
+// the current fixed window and setting the window's expiry on the
+// first request seen in it.
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (bool, int, int, time.Time, error) {
+	count, err := l.Client.Incr(ctx, key).Result()
+	if err != nil {
+		return false, l.Limit, 0, time.Time{}, err
+	}
+	if count == 1 {
+		if err := l.Client.Expire(ctx, key, l.Window).Err(); err != nil {
+			return false, l.Limit, 0, time.Time{}, err
+		}
+	}
+
+	ttl, err := l.Client.TTL(ctx, key).Result()
+	if err != nil {
+		return false, l.Limit, 0, time.Time{}, err
+	}
+
+	remaining := l.Limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return int(count) <= l.Limit, l.Limit, remaining, time.Now().Add(ttl), nil
+}
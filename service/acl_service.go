@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/casbin/casbin"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+// ACLService administers the casbin policy rules that back
+// CasbinAuthorizer, reloading the enforcer's policy from the database
+// any time a rule is added or removed so changes take effect without
+// a redeploy.
+type ACLService struct {
+	Enforcer *casbin.Enforcer
+}
+
+// NewACLService is an initializer for ACLService
+func NewACLService(enforcer *casbin.Enforcer) ACLService {
+	return ACLService{Enforcer: enforcer}
+}
+
+// FindAll returns every policy rule currently enforced
+func (s ACLService) FindAll(ctx context.Context) ([]auth.AccessControlList, error) {
+	rules := s.Enforcer.GetPolicy()
+
+	acls := make([]auth.AccessControlList, 0, len(rules))
+	for _, r := range rules {
+		if len(r) != 3 {
+			continue
+		}
+		acls = append(acls, auth.AccessControlList{Subject: r[0], Object: r[1], Action: r[2]})
+	}
+
+	return acls, nil
+}
+
+// Add adds a policy rule and reloads the enforcer's policy from the
+// database
+func (s ACLService) Add(ctx context.Context, acl auth.AccessControlList) (auth.AccessControlList, error) {
+	added := s.Enforcer.AddPolicy(acl.Subject, acl.Object, acl.Action)
+	if !added {
+		return auth.AccessControlList{}, errs.E(errs.Validation, "policy rule already exists")
+	}
+
+	if err := s.Enforcer.LoadPolicy(); err != nil {
+		return auth.AccessControlList{}, errs.E(errs.Database, err)
+	}
+
+	return acl, nil
+}
+
+// Delete removes a policy rule and reloads the enforcer's policy from
+// the database
+func (s ACLService) Delete(ctx context.Context, acl auth.AccessControlList) error {
+	removed := s.Enforcer.RemovePolicy(acl.Subject, acl.Object, acl.Action)
+	if !removed {
+		return errs.E(errs.NotExist, "policy rule not found")
+	}
+
+	if err := s.Enforcer.LoadPolicy(); err != nil {
+		return errs.E(errs.Database, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/casbin/casbin"
+	"github.com/casbin/casbin/model"
+	"github.com/casbin/casbin/persist"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+)
+
+// newTestACLEnforcer returns a casbin.Enforcer using the repo's real
+// RBAC model, backed by an initially empty memoryAdapter so ACLService
+// can add to, remove from and reload its policy without a database.
+func newTestACLEnforcer(t *testing.T) *casbin.Enforcer {
+	t.Helper()
+
+	modelPath := filepath.Join("..", "domain", "auth", "casbin_model.conf")
+
+	return casbin.NewEnforcer(modelPath, &memoryAdapter{})
+}
+
+// memoryAdapter is an in-memory casbin persist.Adapter. Unlike the
+// file adapter it keeps the rules passed to AddPolicy and
+// RemovePolicy, so they survive the LoadPolicy reload ACLService
+// performs after every change.
+type memoryAdapter struct {
+	lines []string
+}
+
+func (a *memoryAdapter) LoadPolicy(m model.Model) error {
+	for _, line := range a.lines {
+		persist.LoadPolicyLine(line, m)
+	}
+	return nil
+}
+
+func (a *memoryAdapter) SavePolicy(m model.Model) error {
+	return errors.New("not implemented")
+}
+
+func (a *memoryAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	a.lines = append(a.lines, ptype+", "+strings.Join(rule, ", "))
+	return nil
+}
+
+func (a *memoryAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	want := ptype + ", " + strings.Join(rule, ", ")
+	for i, line := range a.lines {
+		if line == want {
+			a.lines = append(a.lines[:i], a.lines[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (a *memoryAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	return errors.New("not implemented")
+}
+
+func TestACLService_AddFindAllDelete(t *testing.T) {
+	ctx := context.Background()
+	e := newTestACLEnforcer(t)
+	s := NewACLService(e)
+
+	acls, err := s.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(acls) != 0 {
+		t.Fatalf("FindAll() = %v, want empty", acls)
+	}
+
+	acl := auth.AccessControlList{Subject: "user", Object: "/api/v1/movies", Action: "read"}
+	added, err := s.Add(ctx, acl)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if added != acl {
+		t.Errorf("Add() = %v, want %v", added, acl)
+	}
+
+	if _, err := s.Add(ctx, acl); err == nil {
+		t.Error("Add() of a duplicate rule error = nil, want error")
+	}
+
+	acls, err = s.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(acls) != 1 || acls[0] != acl {
+		t.Fatalf("FindAll() = %v, want [%v]", acls, acl)
+	}
+
+	if err := s.Delete(ctx, acl); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if err := s.Delete(ctx, acl); err == nil {
+		t.Error("Delete() of an already-removed rule error = nil, want error")
+	}
+
+	acls, err = s.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(acls) != 0 {
+		t.Fatalf("FindAll() = %v, want empty", acls)
+	}
+}
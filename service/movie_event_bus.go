@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// MovieEventType identifies the kind of change a MovieEvent describes
+type MovieEventType string
+
+const (
+	// MovieEventCreated is published after a movie is created
+	MovieEventCreated MovieEventType = "created"
+	// MovieEventUpdated is published after a movie is updated
+	MovieEventUpdated MovieEventType = "updated"
+	// MovieEventDeleted is published after a movie is deleted
+	MovieEventDeleted MovieEventType = "deleted"
+)
+
+// MovieEvent describes a single create/update/delete change to a
+// movie, published after the underlying mutation has committed
+type MovieEvent struct {
+	Type       MovieEventType `json:"type"`
+	ExternalID string         `json:"external_id"`
+	Movie      interface{}    `json:"movie,omitempty"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+// MovieEventBus fans out movie change events to subscribers, e.g. the
+// /api/v1/movies/events streaming endpoint. CreateMovieService,
+// UpdateMovieService and DeleteMovieService each publish an event
+// after their mutation commits.
+type MovieEventBus interface {
+	// Publish broadcasts event to the current subscribers; a
+	// subscriber that subscribes later does not receive it
+	Publish(ctx context.Context, event MovieEvent) error
+	// Subscribe returns a channel of events and an unsubscribe func
+	// that releases any resources associated with the subscription.
+	// The channel is closed after unsubscribe is called. Whether ctx
+	// being done also closes it depends on the implementation:
+	// datastore.PostgresMovieEventBus closes it, InProcessMovieEventBus
+	// does not.
+	Subscribe(ctx context.Context) (<-chan MovieEvent, func(), error)
+}
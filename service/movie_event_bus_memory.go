@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcessMovieEventBus fans out movie change events to every
+// subscriber within this process. It is the default MovieEventBus;
+// it does not survive across instances, unlike
+// datastore.PostgresMovieEventBus.
+type InProcessMovieEventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan MovieEvent]struct{}
+}
+
+// NewInProcessMovieEventBus is an initializer for
+// InProcessMovieEventBus
+func NewInProcessMovieEventBus() *InProcessMovieEventBus {
+	return &InProcessMovieEventBus{subscribers: make(map[chan MovieEvent]struct{})}
+}
+
+// Publish sends event to every current subscriber. A subscriber that
+// isn't keeping up has the event dropped rather than blocking the
+// publisher.
+func (b *InProcessMovieEventBus) Publish(ctx context.Context, event MovieEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a new subscriber and returns its event channel
+// along with a func that unregisters it
+func (b *InProcessMovieEventBus) Subscribe(ctx context.Context) (<-chan MovieEvent, func(), error) {
+	ch := make(chan MovieEvent, 16)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+
+	return ch, unsubscribe, nil
+}
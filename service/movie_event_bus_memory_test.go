@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInProcessMovieEventBus_PublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	b := NewInProcessMovieEventBus()
+
+	ch, unsubscribe, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	event := MovieEvent{Type: MovieEventCreated, ExternalID: "abc123", OccurredAt: time.Now()}
+	if err := b.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case got := <-ch:
+		if got != event {
+			t.Errorf("received event = %+v, want %+v", got, event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for published event")
+	}
+
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Error("channel still open after unsubscribe, want closed")
+	}
+}
+
+func TestInProcessMovieEventBus_PublishWithNoSubscribers(t *testing.T) {
+	ctx := context.Background()
+	b := NewInProcessMovieEventBus()
+
+	if err := b.Publish(ctx, MovieEvent{Type: MovieEventDeleted, ExternalID: "abc123"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+func TestInProcessMovieEventBus_PublishDoesNotBlockOnSlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	b := NewInProcessMovieEventBus()
+
+	ch, unsubscribe, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			_ = b.Publish(ctx, MovieEvent{Type: MovieEventUpdated, ExternalID: "abc123"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish() blocked with a subscriber that never drains its channel")
+	}
+
+	<-ch
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/gilcrest/go-api-basic/domain/auth"
+	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// OAuthTokenAuthenticator authenticates Bearer tokens issued by
+// OAuthService's authorization-code grant, so a third-party client's
+// scoped access token can actually be used to call the API. It
+// implements auth.Authenticator and is typically combined with the
+// server's other Authenticator(s) via auth.AuthenticatorChain, since a
+// deployment still needs to authenticate its own users' sessions too.
+type OAuthTokenAuthenticator struct {
+	Tokens OAuthTokenStore
+}
+
+// NewOAuthTokenAuthenticator is an initializer for
+// OAuthTokenAuthenticator
+func NewOAuthTokenAuthenticator(tokens OAuthTokenStore) OAuthTokenAuthenticator {
+	return OAuthTokenAuthenticator{Tokens: tokens}
+}
+
+// Authenticate looks up at as a token issued by OAuthService,
+// rejecting it if it is unknown, revoked or expired, and returns the
+// user.User it was issued on behalf of along with the scopes it was
+// granted.
+func (a OAuthTokenAuthenticator) Authenticate(ctx context.Context, at auth.AccessToken) (user.User, []string, error) {
+	if at.TokenType != auth.BearerTokenType {
+		return user.User{}, nil, errs.NewUnauthorizedError(errors.Errorf("unsupported token type %s", at.TokenType))
+	}
+
+	t, err := a.Tokens.FindByAccessToken(ctx, at.Token)
+	if err != nil {
+		return user.User{}, nil, errs.NewUnauthorizedError(errors.New("invalid access token"))
+	}
+	if t.Revoked || t.Expired() {
+		return user.User{}, nil, errs.NewUnauthorizedError(errors.New("access token is revoked or expired"))
+	}
+
+	return user.User{Email: t.UserID}, t.Scopes, nil
+}
+
+// Scheme returns the auth-scheme advertised in the WWW-Authenticate
+// challenge header when authentication fails
+func (a OAuthTokenAuthenticator) Scheme() string {
+	return auth.BearerTokenType
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// OAuthClient is a registered third-party application allowed to act
+// on behalf of a user via the OAuth2 authorization-code grant.
+type OAuthClient struct {
+	ClientID         string
+	ClientSecretHash []byte
+	RedirectURI      string
+	AllowedScopes    []string
+}
+
+// HashClientSecret returns the sha256 hash of secret, for storing in
+// OAuthClient.ClientSecretHash and later comparing against via
+// AuthenticatesWith rather than persisting secret itself.
+func HashClientSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// AuthenticatesWith reports, in constant time, whether secret is the
+// client's registered secret.
+func (c OAuthClient) AuthenticatesWith(secret string) bool {
+	return subtle.ConstantTimeCompare(c.ClientSecretHash, HashClientSecret(secret)) == 1
+}
+
+// HasScope reports whether scope is one of the client's allowed
+// scopes, e.g. "movies:read"
+func (c OAuthClient) HasScope(scope string) bool {
+	for _, s := range c.AllowedScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthClientStore looks up registered OAuthClients
+type OAuthClientStore interface {
+	FindByClientID(ctx context.Context, clientID string) (OAuthClient, error)
+}
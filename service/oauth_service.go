@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+const (
+	authorizationCodeTTL = 5 * time.Minute
+	oauthAccessTokenTTL  = time.Hour
+)
+
+// OAuthService implements the OAuth2 authorization-code grant
+// (RFC 6749) plus revocation (RFC 7009) and introspection (RFC 7662).
+// It lets third-party clients act on a user's behalf with scoped,
+// revocable tokens instead of forwarding the user's own Google
+// bearer token.
+type OAuthService struct {
+	Clients OAuthClientStore
+	Tokens  OAuthTokenStore
+}
+
+// NewOAuthService is an initializer for OAuthService
+func NewOAuthService(clients OAuthClientStore, tokens OAuthTokenStore) OAuthService {
+	return OAuthService{Clients: clients, Tokens: tokens}
+}
+
+// Authorize issues an authorization code for userID, scoped to
+// scopes, after validating that clientID is registered, redirectURI
+// matches the client's registration, and every requested scope is
+// allowed for that client.
+func (s OAuthService) Authorize(ctx context.Context, clientID, redirectURI, userID string, scopes []string) (AuthorizationCode, error) {
+	client, err := s.Clients.FindByClientID(ctx, clientID)
+	if err != nil {
+		return AuthorizationCode{}, errs.NewUnauthorizedError(err)
+	}
+	if client.RedirectURI != redirectURI {
+		return AuthorizationCode{}, errs.E(errs.Validation, errors.New("redirect_uri does not match registered client"))
+	}
+	for _, scope := range scopes {
+		if !client.HasScope(scope) {
+			return AuthorizationCode{}, errs.E(errs.Validation, errors.New("scope "+scope+" is not allowed for this client"))
+		}
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return AuthorizationCode{}, errs.E(errs.Internal, err)
+	}
+
+	ac := AuthorizationCode{
+		Code:        code,
+		ClientID:    clientID,
+		UserID:      userID,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+		ExpiresAt:   time.Now().Add(authorizationCodeTTL),
+	}
+
+	if err := s.Tokens.SaveCode(ctx, ac); err != nil {
+		return AuthorizationCode{}, errs.E(errs.Database, err)
+	}
+
+	return ac, nil
+}
+
+// Token exchanges code for an access/refresh token pair, validating
+// that clientSecret matches the client that owns code and that code
+// has not expired.
+func (s OAuthService) Token(ctx context.Context, clientID, clientSecret, code string) (OAuthToken, error) {
+	client, err := s.Clients.FindByClientID(ctx, clientID)
+	if err != nil || !client.AuthenticatesWith(clientSecret) {
+		return OAuthToken{}, errs.NewUnauthorizedError(errors.New("invalid client credentials"))
+	}
+
+	ac, err := s.Tokens.FindCode(ctx, code)
+	if err != nil || ac.ClientID != clientID || ac.Expired() {
+		return OAuthToken{}, errs.NewUnauthorizedError(errors.New("invalid authorization code"))
+	}
+
+	if err := s.Tokens.DeleteCode(ctx, code); err != nil {
+		return OAuthToken{}, errs.E(errs.Database, err)
+	}
+
+	return s.issueToken(ctx, clientID, ac.UserID, ac.Scopes, "")
+}
+
+// Refresh exchanges refreshToken for a new access token, carrying the
+// original scopes forward.
+func (s OAuthService) Refresh(ctx context.Context, clientID, clientSecret, refreshToken string) (OAuthToken, error) {
+	client, err := s.Clients.FindByClientID(ctx, clientID)
+	if err != nil || !client.AuthenticatesWith(clientSecret) {
+		return OAuthToken{}, errs.NewUnauthorizedError(errors.New("invalid client credentials"))
+	}
+
+	existing, err := s.Tokens.FindByRefreshToken(ctx, refreshToken)
+	if err != nil || existing.Revoked || existing.ClientID != clientID {
+		return OAuthToken{}, errs.NewUnauthorizedError(errors.New("invalid refresh token"))
+	}
+
+	return s.issueToken(ctx, clientID, existing.UserID, existing.Scopes, refreshToken)
+}
+
+// issueToken mints and persists a new access token for clientID/userID
+// scoped to scopes, reusing refreshToken if one is given (the Refresh
+// flow) or minting a new one otherwise (the Token/authorization_code
+// flow).
+func (s OAuthService) issueToken(ctx context.Context, clientID, userID string, scopes []string, refreshToken string) (OAuthToken, error) {
+	access, err := randomToken()
+	if err != nil {
+		return OAuthToken{}, errs.E(errs.Internal, err)
+	}
+	if refreshToken == "" {
+		refreshToken, err = randomToken()
+		if err != nil {
+			return OAuthToken{}, errs.E(errs.Internal, err)
+		}
+	}
+
+	t := OAuthToken{
+		AccessToken:  access,
+		RefreshToken: refreshToken,
+		ClientID:     clientID,
+		UserID:       userID,
+		Scopes:       scopes,
+		ExpiresAt:    time.Now().Add(oauthAccessTokenTTL),
+	}
+
+	if err := s.Tokens.SaveToken(ctx, t); err != nil {
+		return OAuthToken{}, errs.E(errs.Database, err)
+	}
+
+	return t, nil
+}
+
+// Revoke invalidates accessToken, per RFC 7009. clientID/clientSecret
+// must authenticate the client that was issued accessToken, per
+// RFC 7009 §2.1.
+func (s OAuthService) Revoke(ctx context.Context, clientID, clientSecret, accessToken string) error {
+	client, err := s.Clients.FindByClientID(ctx, clientID)
+	if err != nil || !client.AuthenticatesWith(clientSecret) {
+		return errs.NewUnauthorizedError(errors.New("invalid client credentials"))
+	}
+
+	t, err := s.Tokens.FindByAccessToken(ctx, accessToken)
+	if err != nil {
+		// An already-unknown token is not an error, per RFC 7009 §2.2.
+		return nil
+	}
+	if t.ClientID != client.ClientID {
+		return errs.NewUnauthorizedError(errors.New("token was not issued to this client"))
+	}
+
+	if err := s.Tokens.Revoke(ctx, accessToken); err != nil {
+		return errs.E(errs.Database, err)
+	}
+	return nil
+}
+
+// IntrospectionResponse is the RFC 7662 token introspection response
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	UserID    string `json:"sub,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// Introspect reports whether accessToken is currently active, per
+// RFC 7662. clientID/clientSecret must authenticate the calling
+// client, per RFC 7662 §2.1. An unknown, revoked, expired token, or
+// one issued to a different client, is reported as inactive rather
+// than as an error, per the RFC.
+func (s OAuthService) Introspect(ctx context.Context, clientID, clientSecret, accessToken string) (IntrospectionResponse, error) {
+	client, err := s.Clients.FindByClientID(ctx, clientID)
+	if err != nil || !client.AuthenticatesWith(clientSecret) {
+		return IntrospectionResponse{}, errs.NewUnauthorizedError(errors.New("invalid client credentials"))
+	}
+
+	t, err := s.Tokens.FindByAccessToken(ctx, accessToken)
+	if err != nil || t.Revoked || t.Expired() || t.ClientID != client.ClientID {
+		return IntrospectionResponse{Active: false}, nil
+	}
+
+	return IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(t.Scopes, " "),
+		ClientID:  t.ClientID,
+		UserID:    t.UserID,
+		ExpiresAt: t.ExpiresAt.Unix(),
+	}, nil
+}
+
+// randomToken generates a URL-safe random token suitable for use as
+// an authorization code or bearer token
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
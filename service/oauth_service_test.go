@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeOAuthStore is an in-memory OAuthClientStore and OAuthTokenStore
+// used to test OAuthService without a database.
+type fakeOAuthStore struct {
+	clients map[string]OAuthClient
+	codes   map[string]AuthorizationCode
+	tokens  map[string]OAuthToken
+}
+
+func newFakeOAuthStore(clients ...OAuthClient) *fakeOAuthStore {
+	s := &fakeOAuthStore{
+		clients: make(map[string]OAuthClient),
+		codes:   make(map[string]AuthorizationCode),
+		tokens:  make(map[string]OAuthToken),
+	}
+	for _, c := range clients {
+		s.clients[c.ClientID] = c
+	}
+	return s
+}
+
+func (s *fakeOAuthStore) FindByClientID(ctx context.Context, clientID string) (OAuthClient, error) {
+	c, ok := s.clients[clientID]
+	if !ok {
+		return OAuthClient{}, errNotFound
+	}
+	return c, nil
+}
+
+func (s *fakeOAuthStore) SaveCode(ctx context.Context, code AuthorizationCode) error {
+	s.codes[code.Code] = code
+	return nil
+}
+
+func (s *fakeOAuthStore) FindCode(ctx context.Context, code string) (AuthorizationCode, error) {
+	ac, ok := s.codes[code]
+	if !ok {
+		return AuthorizationCode{}, errNotFound
+	}
+	return ac, nil
+}
+
+func (s *fakeOAuthStore) DeleteCode(ctx context.Context, code string) error {
+	delete(s.codes, code)
+	return nil
+}
+
+func (s *fakeOAuthStore) SaveToken(ctx context.Context, token OAuthToken) error {
+	s.tokens[token.AccessToken] = token
+	s.tokens["refresh:"+token.RefreshToken] = token
+	return nil
+}
+
+func (s *fakeOAuthStore) FindByAccessToken(ctx context.Context, accessToken string) (OAuthToken, error) {
+	t, ok := s.tokens[accessToken]
+	if !ok {
+		return OAuthToken{}, errNotFound
+	}
+	return t, nil
+}
+
+func (s *fakeOAuthStore) FindByRefreshToken(ctx context.Context, refreshToken string) (OAuthToken, error) {
+	t, ok := s.tokens["refresh:"+refreshToken]
+	if !ok {
+		return OAuthToken{}, errNotFound
+	}
+	return t, nil
+}
+
+func (s *fakeOAuthStore) Revoke(ctx context.Context, accessToken string) error {
+	t, ok := s.tokens[accessToken]
+	if !ok {
+		return errNotFound
+	}
+	t.Revoked = true
+	s.tokens[accessToken] = t
+	s.tokens["refresh:"+t.RefreshToken] = t
+	return nil
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string { return "not found" }
+
+var errNotFound = notFoundError{}
+
+func testClient() OAuthClient {
+	return OAuthClient{
+		ClientID:         "client-1",
+		ClientSecretHash: HashClientSecret("s3cr3t"),
+		RedirectURI:      "https://example.com/callback",
+		AllowedScopes:    []string{"movies:read", "movies:write"},
+	}
+}
+
+func TestOAuthService_AuthorizeTokenRefresh(t *testing.T) {
+	ctx := context.Background()
+	client := testClient()
+	store := newFakeOAuthStore(client)
+	s := NewOAuthService(store, store)
+
+	code, err := s.Authorize(ctx, client.ClientID, client.RedirectURI, "otto.maddox711@gmail.com", []string{"movies:read"})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+
+	if _, err := s.Authorize(ctx, client.ClientID, client.RedirectURI, "otto.maddox711@gmail.com", []string{"movies:delete"}); err == nil {
+		t.Error("Authorize() with disallowed scope error = nil, want error")
+	}
+
+	if _, err := s.Authorize(ctx, client.ClientID, "https://evil.example.com", "otto.maddox711@gmail.com", nil); err == nil {
+		t.Error("Authorize() with mismatched redirect_uri error = nil, want error")
+	}
+
+	if _, err := s.Token(ctx, client.ClientID, "wrong-secret", code.Code); err == nil {
+		t.Error("Token() with wrong client secret error = nil, want error")
+	}
+
+	tok, err := s.Token(ctx, client.ClientID, "s3cr3t", code.Code)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tok.AccessToken == "" || tok.RefreshToken == "" {
+		t.Errorf("Token() = %+v, want non-empty access/refresh tokens", tok)
+	}
+
+	if _, err := s.Token(ctx, client.ClientID, "s3cr3t", code.Code); err == nil {
+		t.Error("Token() with already-exchanged code error = nil, want error")
+	}
+
+	refreshed, err := s.Refresh(ctx, client.ClientID, "s3cr3t", tok.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.AccessToken == tok.AccessToken {
+		t.Error("Refresh() returned the same access token, want a newly minted one")
+	}
+	if refreshed.RefreshToken != tok.RefreshToken {
+		t.Errorf("Refresh() rotated the refresh token, want it unchanged: got %s, want %s", refreshed.RefreshToken, tok.RefreshToken)
+	}
+
+	if _, err := s.Refresh(ctx, client.ClientID, "wrong-secret", tok.RefreshToken); err == nil {
+		t.Error("Refresh() with wrong client secret error = nil, want error")
+	}
+}
+
+func TestOAuthService_RevokeIntrospect(t *testing.T) {
+	ctx := context.Background()
+	client := testClient()
+	other := OAuthClient{ClientID: "client-2", ClientSecretHash: HashClientSecret("other-secret"), RedirectURI: "https://other.example.com"}
+	store := newFakeOAuthStore(client, other)
+	s := NewOAuthService(store, store)
+
+	code, err := s.Authorize(ctx, client.ClientID, client.RedirectURI, "otto.maddox711@gmail.com", []string{"movies:read"})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	tok, err := s.Token(ctx, client.ClientID, "s3cr3t", code.Code)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	if err := s.Revoke(ctx, client.ClientID, "wrong-secret", tok.AccessToken); err == nil {
+		t.Error("Revoke() with wrong client credentials error = nil, want error")
+	}
+
+	if err := s.Revoke(ctx, other.ClientID, "other-secret", tok.AccessToken); err == nil {
+		t.Error("Revoke() of a token owned by a different client error = nil, want error")
+	}
+
+	resp, err := s.Introspect(ctx, client.ClientID, "s3cr3t", tok.AccessToken)
+	if err != nil {
+		t.Fatalf("Introspect() error = %v", err)
+	}
+	if !resp.Active {
+		t.Error("Introspect() Active = false before revocation, want true")
+	}
+
+	if err := s.Revoke(ctx, client.ClientID, "s3cr3t", tok.AccessToken); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	resp, err = s.Introspect(ctx, client.ClientID, "s3cr3t", tok.AccessToken)
+	if err != nil {
+		t.Fatalf("Introspect() error = %v", err)
+	}
+	if resp.Active {
+		t.Error("Introspect() Active = true after revocation, want false")
+	}
+
+	if _, err := s.Introspect(ctx, client.ClientID, "wrong-secret", tok.AccessToken); err == nil {
+		t.Error("Introspect() with wrong client credentials error = nil, want error")
+	}
+
+	if resp, err := s.Introspect(ctx, other.ClientID, "other-secret", tok.AccessToken); err != nil || resp.Active {
+		t.Errorf("Introspect() by a different client = %+v, err = %v, want inactive, no error", resp, err)
+	}
+}
+
+func TestOAuthService_AuthorizeExpiredCode(t *testing.T) {
+	ctx := context.Background()
+	client := testClient()
+	store := newFakeOAuthStore(client)
+	s := NewOAuthService(store, store)
+
+	ac := AuthorizationCode{
+		Code:        "expired-code",
+		ClientID:    client.ClientID,
+		UserID:      "otto.maddox711@gmail.com",
+		RedirectURI: client.RedirectURI,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}
+	if err := store.SaveCode(ctx, ac); err != nil {
+		t.Fatalf("SaveCode() error = %v", err)
+	}
+
+	if _, err := s.Token(ctx, client.ClientID, "s3cr3t", ac.Code); err == nil {
+		t.Error("Token() with expired code error = nil, want error")
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// AuthorizationCode is a short-lived code issued at /oauth/authorize
+// and exchanged for an OAuthToken at /oauth/token
+type AuthorizationCode struct {
+	Code        string
+	ClientID    string
+	UserID      string
+	RedirectURI string
+	Scopes      []string
+	ExpiresAt   time.Time
+}
+
+// Expired reports whether the code is past its ExpiresAt
+func (c AuthorizationCode) Expired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// OAuthToken is an access/refresh token pair issued to a client on
+// behalf of a user, limited to Scopes
+type OAuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ClientID     string
+	UserID       string
+	Scopes       []string
+	ExpiresAt    time.Time
+	Revoked      bool
+}
+
+// Expired reports whether the access token is past its ExpiresAt
+func (t OAuthToken) Expired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// OAuthTokenStore persists authorization codes and issued tokens for
+// the OAuth2 subsystem
+type OAuthTokenStore interface {
+	SaveCode(ctx context.Context, code AuthorizationCode) error
+	FindCode(ctx context.Context, code string) (AuthorizationCode, error)
+	DeleteCode(ctx context.Context, code string) error
+
+	SaveToken(ctx context.Context, token OAuthToken) error
+	FindByAccessToken(ctx context.Context, accessToken string) (OAuthToken, error)
+	FindByRefreshToken(ctx context.Context, refreshToken string) (OAuthToken, error)
+	Revoke(ctx context.Context, accessToken string) error
+}